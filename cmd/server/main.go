@@ -3,16 +3,24 @@
 package main
 
 import (
-	"context"   //上下文管理
-	"fmt"       //格式化输出
-	"log"       //日志记录
-	"net/http"  //HTTP服务器
-	"os"        //操作系统接口
-	"os/signal" //信号处理
-	"syscall"   //系统调用
-	"time"      //时间处理
+	"context"            //上下文管理
+	"fmt"                //格式化输出
+	"io"                 //EOF判断
+	"log"                //日志记录
+	"net"                //网络监听器
+	"net/http"           //HTTP服务器
+	"os"                 //操作系统接口
+	"os/exec"            //子进程管理
+	"os/signal"          //信号处理
+	stdruntime "runtime" //导出goroutine调用栈,与下方internal/runtime区分
+	"strconv"            //解析LISTEN_FDS
+	"sync"               //sync.Once防止重复关闭
+	"sync/atomic"        //原子操作
+	"syscall"            //系统调用
+	"time"               //时间处理
 
 	"github.com/gin-gonic/gin" //WEB框架
+	"google.golang.org/grpc"   //gRPC服务器
 
 	//内部包
 	"github.com/Tencent/WeKnora/internal/application/service"
@@ -23,6 +31,42 @@ import (
 	"github.com/Tencent/WeKnora/internal/types/interfaces"
 )
 
+// gracefulEnvFlag marks a process as a hot-reloaded child that inherits its
+// listener fd instead of binding a fresh socket.
+// 标记当前进程是通过热重启继承监听fd启动的子进程
+const gracefulEnvFlag = "WEKNORA_GRACEFUL"
+
+// listenFDsEnvFlag tells the child how many fds were inherited via ExtraFiles.
+// 告知子进程通过ExtraFiles继承了多少个fd
+const listenFDsEnvFlag = "LISTEN_FDS"
+
+// gracefulListenerFD is the fixed fd index (after stdin/stdout/stderr) the
+// listening socket is passed on.
+// 监听socket继承时固定使用的fd编号(标准输入输出错误之后的第一个)
+const gracefulListenerFD = 3
+
+// gracefulReadyFD is the fd the child writes a single byte to once it has
+// bound the inherited listener, so the parent knows it's safe to stop.
+// 子进程绑定继承的监听器后,向该fd写入一个字节通知父进程可以安全退出
+const gracefulReadyFD = 4
+
+// Resource cleanup runs in priority tiers: the HTTP server drain happens
+// first (outside the cleaner, via server.Shutdown), then app services, then
+// infra such as databases, tracing and message queues last. Lower numbers
+// run earlier; hooks within the same tier run concurrently.
+// 资源清理按优先级分层执行:HTTP服务器排空最先(在清理器之外通过server.Shutdown完成),
+// 然后是应用服务层,最后是数据库/链路追踪/消息队列等基础设施层。数值越小越先执行,同层内并发执行
+const (
+	cleanupPriorityAppServices = 10
+	cleanupPriorityInfra       = 20
+)
+
+// tracerCleanupTimeout bounds how long the tracer gets to flush on shutdown.
+// Its context is derived from the overall shutdown ceiling, so whichever
+// deadline comes first applies.
+// 链路追踪器在关闭时刷新数据的独立超时时间;其上下文派生自整体关闭超时上限,以先到期者为准
+const tracerCleanupTimeout = 10 * time.Second
+
 func main() {
 	// Set log format with request ID
 	//初始化日志
@@ -60,74 +104,422 @@ func main() {
 		cleanupCtx, cleanupCancel := context.WithTimeout(context.Background(), shutdownTimeout)
 		defer cleanupCancel()
 
-		// Register tracer cleanup function to resource cleaner
-		// 将链路追踪器注册到资源清理器
-		resourceCleaner.RegisterWithName("Tracer", func() error {
-			return tracer.Cleanup(cleanupCtx)
-		})
-
-		// Initialize test data
-		//测试数据初始化
-		if testDataService != nil {
-			if err := testDataService.InitializeTestData(context.Background()); err != nil {
-				log.Printf("Failed to initialize test data: %v", err)
-			}
+		// Register tracer cleanup function to resource cleaner.
+		// Tracing is infra, so it runs in the last tier and gets its own
+		// timeout, derived from (and still capped by) the overall shutdown
+		// ceiling.
+		// 将链路追踪器注册到资源清理器:属于基础设施层,放在最后一档清理,并使用派生自整体上限的独立超时
+		resourceCleaner.RegisterWithNameAndTimeout("Tracer", tracerCleanupTimeout, cleanupPriorityInfra,
+			func(ctx context.Context) error {
+				return tracer.Cleanup(ctx)
+			})
+
+		// Startup/shutdown components with explicit dependencies, in place of
+		// a hard-coded sequence. This is where future subsystems (model
+		// pre-warm, vector index load, cache hydration, crontabs) register
+		// themselves instead of main growing another if-block.
+		// 使用带显式依赖关系的启动/关闭组件取代硬编码的执行顺序。
+		// 未来的子系统(模型预热、向量索引加载、缓存预热、定时任务)应在此注册,而不是让main再增加一个if分支
+		lc := runtime.NewLifecycle()
+		if err := lc.Register(runtime.Component{
+			Name: "TestData",
+			Start: func(ctx context.Context) error {
+				if testDataService == nil {
+					return nil
+				}
+				return testDataService.InitializeTestData(ctx)
+			},
+		}); err != nil {
+			log.Printf("Failed to register TestData component: %v", err)
 		}
+		if err := lc.Run(context.Background()); err != nil {
+			log.Printf("Failed to run startup components: %v", err)
+		}
+
+		// ready reports whether the server should be considered part of the
+		// Kubernetes service endpoints. It starts false until the listener is
+		// up, flips false again the moment a shutdown signal arrives so
+		// kube-proxy can drain us before Shutdown actually runs, and can also
+		// be toggled by SIGUSR1 for manual debugging.
+		// ready表示当前实例是否应被纳入K8s服务端点:监听器就绪前为false,
+		// 收到关闭信号时立即置为false以便kube-proxy先摘除流量,也可通过SIGUSR1手动切换用于调试
+		var ready atomic.Bool
+		registerLifecycleEndpoints(router, &ready)
+
+		// shuttingDown is set the moment a real shutdown starts, so a
+		// SIGUSR1 racing with gracefulShutdown's PreStopDelay can't flip
+		// readiness back to true and undo the endpoint removal it's
+		// waiting on.
+		// shuttingDown在真正的关闭流程开始时被置位,防止SIGUSR1与gracefulShutdown的
+		// PreStopDelay竞争时把就绪状态重新置为true,撤销正在等待生效的端点摘除
+		var shuttingDown atomic.Bool
+
+		addr := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port)
+
+		// Obtain the listener ourselves instead of letting http.Server dial it,
+		// so the underlying fd can be handed off to a hot-reloaded child.
+		// 自行创建监听器而不是交给http.Server,这样fd才能在热重启时传递给子进程
+		listener, err := acquireListener(addr)
+		if err != nil {
+			return fmt.Errorf("failed to acquire listener: %v", err)
+		}
+		signalGracefulParentReady()
+		ready.Store(true)
 
 		// Create HTTP server
 		//创建HTTP服务器
 		server := &http.Server{
-			Addr:    fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port),
+			Addr:    addr,
 			Handler: router, //使用依赖注入的Gin路由
 		}
 
+		// Bring up the gRPC and admin/metrics listeners alongside the HTTP
+		// API server. All three run under one serverGroup so they share a
+		// single shutdown sequence instead of three independent ones.
+		// 在HTTP API服务器之外同时启动gRPC和管理/指标监听器,
+		// 三者在同一个serverGroup下运行,共享一套关闭流程而不是各自独立关闭
+		group := newServerGroup()
+		group.Register(newHTTPManagedServer("HTTP API", server, listener))
+
+		grpcAddr := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.GRPC.Port)
+		grpcListener, err := net.Listen("tcp", grpcAddr)
+		if err != nil {
+			return fmt.Errorf("failed to listen on gRPC address %s: %v", grpcAddr, err)
+		}
+		grpcServer := grpc.NewServer()
+		group.Register(newGRPCManagedServer("gRPC", grpcServer, grpcListener))
+
+		adminAddr := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Admin.Port)
+		adminListener, err := net.Listen("tcp", adminAddr)
+		if err != nil {
+			return fmt.Errorf("failed to listen on admin address %s: %v", adminAddr, err)
+		}
+		adminServer := &http.Server{Addr: adminAddr, Handler: newAdminMux()}
+		group.Register(newHTTPManagedServer("Admin/metrics", adminServer, adminListener))
+
 		ctx, done := context.WithCancel(context.Background())
-		//信号处理-优雅关闭
+
+		// shutdownOnce guards the actual drain-and-cleanup sequence so it
+		// only ever runs once, whichever path triggers it first: a real
+		// termination signal, a hot-reload handoff, or a fatal server error.
+		// shutdownOnce确保排空清理流程只执行一次,无论是真正的终止信号、
+		// 热重启交接还是服务器致命错误,都只会触发一次
+		var shutdownOnce sync.Once
+
 		//信号监听
-		signals := make(chan os.Signal, 1)
 		//syscall.SIGINT Ctrl+C中断信号,
-		//syscall.SIGTERM 终止信号(Docker stop),
-		//syscall.SIGHUP 挂起信号
-		signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
-		//优雅关闭流程
+		//syscall.SIGTERM 终止信号(Docker stop)
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+
+		// SIGUSR1 toggles readiness without touching the server at all, so
+		// operators can pull a pod out of rotation for debugging and put it
+		// back without restarting it.
+		// SIGUSR1仅切换就绪状态而不影响服务本身,便于运维在调试时手动摘除/恢复流量而无需重启
+		usr1Signals := make(chan os.Signal, 1)
+		signal.Notify(usr1Signals, syscall.SIGUSR1)
 		go func() {
-			sig := <-signals
-			log.Printf("Received signal: %v, starting server shutdown...", sig)
+			for range usr1Signals {
+				if shuttingDown.Load() {
+					log.Println("SIGUSR1 received, ignoring: shutdown already in progress")
+					continue
+				}
+				toggled := !ready.Load()
+				ready.Store(toggled)
+				log.Printf("SIGUSR1 received, readiness manually toggled to %v", toggled)
+			}
+		}()
 
-			// Create a context with timeout for server shutdown
-			//关闭HTTP服务器(停止接受新请求，等待现有请求完成)
-			shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
-			defer shutdownCancel()
+		// SIGHUP is handled separately from shutdown signals: it triggers a
+		// zero-downtime hot restart instead of a drain.
+		// SIGHUP单独处理:触发零停机热重启而不是关闭流程
+		hupSignals := make(chan os.Signal, 1)
+		signal.Notify(hupSignals, syscall.SIGHUP)
 
-			if err := server.Shutdown(shutdownCtx); err != nil {
-				log.Fatalf("Server forced to shutdown: %v", err)
+		go func() {
+			for range hupSignals {
+				log.Println("Received SIGHUP, starting hot restart...")
+				if err := spawnGracefulChild(listener); err != nil {
+					log.Printf("Hot restart failed, keeping current process: %v", err)
+					continue
+				}
+				// The child already owns the listening socket and the
+				// Pod/IP never stopped serving, so skip the PreStop
+				// readiness dance (it only matters for actual Pod
+				// termination) and drain the old process immediately.
+				// 子进程已经持有监听socket,Pod/IP从未停止对外服务,
+				// 因此跳过PreStop就绪摘除流程(该流程只在Pod真正终止时才有意义),直接排空旧进程
+				log.Println("Child process is ready, draining old listener without PreStop delay")
+				shuttingDown.Store(true)
+				shutdownOnce.Do(func() {
+					drainAndCleanup(group, lc, resourceCleaner, cleanupCtx, done)
+				})
 			}
+		}()
 
-			// Clean up all registered resources
-			//清理所有注册的资源
-			log.Println("Cleaning up resources...")
-			errs := resourceCleaner.Cleanup(cleanupCtx)
-			if len(errs) > 0 {
-				log.Printf("Errors occurred during resource cleanup: %v", errs)
+		// SIGQUIT always dumps goroutine stacks for diagnostics and never
+		// exits, regardless of how far shutdown has progressed.
+		// SIGQUIT始终用于转储goroutine调用栈以便诊断,不会导致进程退出,无论关闭流程进行到哪一步
+		quitSignals := make(chan os.Signal, 1)
+		signal.Notify(quitSignals, syscall.SIGQUIT)
+		go func() {
+			for range quitSignals {
+				log.Println("Received SIGQUIT, dumping goroutine stacks")
+				dumpGoroutineStacks()
 			}
+		}()
 
-			log.Println("Server has exited")
-			done() //通知主流程关闭完成
+		//优雅关闭流程: 第一次信号触发排空关闭,重复信号可升级为强制退出
+		go func() {
+			shutdownSignalCount := 0
+			for sig := range signals {
+				shutdownSignalCount++
+				switch shutdownSignalCount {
+				case 1:
+					log.Printf("Received signal: %v, starting server shutdown...", sig)
+					go shutdownOnce.Do(func() {
+						gracefulShutdown(cfg, group, lc, resourceCleaner, cleanupCtx, &ready, &shuttingDown, done)
+					})
+				case 2:
+					log.Println("Shutdown already in progress, send once more to force exit")
+				default:
+					log.Println("Force exit requested, dumping goroutine stacks before exiting")
+					dumpGoroutineStacks()
+					os.Exit(1)
+				}
+			}
 		}()
 
-		// Start server
-		//启动服务
-		log.Printf("Server is running at %s:%d", cfg.Server.Host, cfg.Server.Port)
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			return fmt.Errorf("failed to start server: %v", err)
-		}
+		// Start all servers. A panic or fatal error in any one of them is
+		// treated like an incoming shutdown signal for the rest.
+		// 启动所有服务器。任意一个发生panic或致命错误都会被当作收到关闭信号,触发其余服务器一并关闭
+		log.Printf("HTTP API listening at %s (graceful=%v), gRPC at %s, admin at %s",
+			addr, os.Getenv(gracefulEnvFlag) == "1", grpcAddr, adminAddr)
+		fatal := group.Run()
 
-		// Wait for shutdown signal
-		//等待关闭信号
-		<-ctx.Done()
+		// Wait for shutdown signal or a fatal error from any server
+		//等待关闭信号,或等待任意服务器报告致命错误
+		select {
+		case <-ctx.Done():
+		case fatalErr := <-fatal:
+			log.Printf("%v, triggering shutdown of the remaining servers", fatalErr)
+			signals <- syscall.SIGTERM
+			<-ctx.Done()
+		}
 		return nil
 	})
 	if err != nil {
 		log.Fatalf("Failed to run application: %v", err)
 	}
 }
+
+// acquireListener binds a fresh TCP listener, unless the process was started
+// as a hot-reload child (WEKNORA_GRACEFUL=1), in which case it wraps the fd
+// inherited from the parent via ExtraFiles instead.
+// 创建TCP监听器;如果进程是热重启子进程(WEKNORA_GRACEFUL=1),则改为包装从父进程通过ExtraFiles继承的fd
+func acquireListener(addr string) (net.Listener, error) {
+	if os.Getenv(gracefulEnvFlag) == "1" {
+		fdCount, err := strconv.Atoi(os.Getenv(listenFDsEnvFlag))
+		if err != nil || fdCount < 1 {
+			return nil, fmt.Errorf(
+				"graceful restart requires %s to report at least 1 inherited fd, got %q",
+				listenFDsEnvFlag, os.Getenv(listenFDsEnvFlag))
+		}
+
+		file := os.NewFile(uintptr(gracefulListenerFD), "listener")
+		if file == nil {
+			return nil, fmt.Errorf("inherited listener fd %d is not available", gracefulListenerFD)
+		}
+		listener, err := net.FileListener(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to wrap inherited listener fd: %v", err)
+		}
+		// net.FileListener duplicated the fd; close the original once the
+		// net.Listener owns its copy to avoid leaking descriptors across
+		// further restarts.
+		file.Close()
+		log.Println("Inherited listener fd from parent process")
+		return listener, nil
+	}
+
+	return net.Listen("tcp", addr)
+}
+
+// signalGracefulParentReady notifies the parent (if this process was started
+// as a hot-reload child) that the inherited listener is bound and serving.
+// 如果当前进程是热重启子进程,通知父进程继承的监听器已绑定并开始提供服务
+func signalGracefulParentReady() {
+	if os.Getenv(gracefulEnvFlag) != "1" {
+		return
+	}
+	readyFile := os.NewFile(uintptr(gracefulReadyFD), "ready")
+	if readyFile == nil {
+		return
+	}
+	defer readyFile.Close()
+	if _, err := readyFile.Write([]byte{1}); err != nil {
+		log.Printf("Failed to signal readiness to parent: %v", err)
+	}
+}
+
+// spawnGracefulChild forks a new copy of the running binary, handing it the
+// listener fd so it can start serving before the parent stops.
+// 派生一个新的二进制进程副本,将监听器fd交给它,使其能在父进程停止前开始提供服务
+func spawnGracefulChild(listener net.Listener) error {
+	tcpListener, ok := listener.(*net.TCPListener)
+	if !ok {
+		return fmt.Errorf("listener of type %T does not support fd inheritance", listener)
+	}
+	listenerFile, err := tcpListener.File()
+	if err != nil {
+		return fmt.Errorf("failed to dup listener fd: %v", err)
+	}
+	defer listenerFile.Close()
+
+	readyReader, readyWriter, err := os.Pipe()
+	if err != nil {
+		return fmt.Errorf("failed to create readiness pipe: %v", err)
+	}
+	defer readyReader.Close()
+
+	cmd := exec.Command(os.Args[0], os.Args[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), gracefulEnvFlag+"=1", listenFDsEnvFlag+"=1")
+	// ExtraFiles[0] lands on fd 3 (gracefulListenerFD), ExtraFiles[1] on fd 4.
+	cmd.ExtraFiles = []*os.File{listenerFile, readyWriter}
+
+	if err := cmd.Start(); err != nil {
+		readyWriter.Close()
+		return fmt.Errorf("failed to start child process: %v", err)
+	}
+	readyWriter.Close()
+
+	// Block until the child writes its readiness byte, signalling that it
+	// has bound the inherited listener and is serving. If the pipe closes
+	// without that byte, the child exited first and the parent keeps serving.
+	buf := make([]byte, 1)
+	if n, err := readyReader.Read(buf); n == 0 {
+		if err == nil || err == io.EOF {
+			err = fmt.Errorf("pipe closed")
+		}
+		return fmt.Errorf("child process did not signal readiness: %v", err)
+	}
+	return nil
+}
+
+// gracefulShutdown runs the drain-then-cleanup sequence once: flip readiness,
+// honor PreStopDelay, stop every managed server, then run resource cleanup.
+// It's spawned in its own goroutine so the signal loop stays free to keep
+// counting repeated signals while this runs.
+// gracefulShutdown执行一次完整的排空后清理流程:置为未就绪、等待PreStopDelay、
+// 停止所有托管服务器、再运行资源清理。它在独立的goroutine中运行,
+// 这样信号处理循环可以继续统计后续重复信号
+func gracefulShutdown(
+	cfg *config.Config,
+	group *serverGroup,
+	lc *runtime.Lifecycle,
+	resourceCleaner interfaces.ResourceCleaner,
+	cleanupCtx context.Context,
+	ready *atomic.Bool,
+	shuttingDown *atomic.Bool,
+	done context.CancelFunc,
+) {
+	// Mark shutdown as started before touching readiness at all, so a
+	// SIGUSR1 racing with the PreStopDelay sleep below sees shuttingDown
+	// and skips toggling ready back to true.
+	// 在改动就绪状态之前先标记关闭已开始,这样与下面PreStopDelay等待竞争的
+	// SIGUSR1会看到shuttingDown已置位,从而跳过将就绪状态重新切回true
+	shuttingDown.Store(true)
+
+	// Flip readiness immediately so /readyz starts failing and kube-proxy
+	// removes us from service endpoints, while the server keeps serving
+	// in-flight and newly-arriving traffic for PreStopDelay before we
+	// actually start draining. This delay is only meaningful for an actual
+	// Pod termination, not the hot-reload handoff (see drainAndCleanup).
+	// 立即将就绪状态置为false,使/readyz开始失败、kube-proxy摘除端点,
+	// 服务器在PreStopDelay时间内继续处理流量,之后才真正开始排空关闭。
+	// 该延迟只在Pod真正终止时才有意义,热重启交接场景见drainAndCleanup
+	ready.Store(false)
+	preStopDelay := cfg.Server.PreStopDelay
+	if preStopDelay == 0 {
+		preStopDelay = 5 * time.Second //默认5秒
+	}
+	log.Printf("Reporting not-ready, waiting %s for endpoint removal before draining", preStopDelay)
+	time.Sleep(preStopDelay)
+
+	drainAndCleanup(group, lc, resourceCleaner, cleanupCtx, done)
+}
+
+// drainAndCleanup stops every managed server, tears down lifecycle
+// components in reverse order, and runs resource cleanup. It's the part of
+// shutdown common to both a real termination signal (after the PreStop
+// delay in gracefulShutdown) and a hot-reload handoff (immediately, since
+// the child already owns the listening socket and no K8s endpoint removal
+// is needed).
+// drainAndCleanup是关闭流程中两条路径共用的部分:停止所有托管服务器、
+// 按相反顺序关闭生命周期组件、运行资源清理。它既用于真正的终止信号
+// (在gracefulShutdown的PreStop延迟之后),也用于热重启交接(立即执行,
+// 因为子进程已经持有监听socket,无需摘除K8s端点)
+func drainAndCleanup(
+	group *serverGroup,
+	lc *runtime.Lifecycle,
+	resourceCleaner interfaces.ResourceCleaner,
+	cleanupCtx context.Context,
+	done context.CancelFunc,
+) {
+	// Create a context with timeout for server shutdown
+	//关闭所有服务器(停止接受新连接，等待现有请求完成)
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer shutdownCancel()
+
+	group.Shutdown(shutdownCtx)
+
+	// Stop startup components in reverse order
+	//按相反顺序停止启动组件
+	if errs := lc.Stop(shutdownCtx); len(errs) > 0 {
+		log.Printf("Errors occurred while stopping lifecycle components: %v", errs)
+	}
+
+	// Clean up all registered resources
+	//清理所有注册的资源
+	log.Println("Cleaning up resources...")
+	errs := resourceCleaner.Cleanup(cleanupCtx)
+	if len(errs) > 0 {
+		log.Printf("Errors occurred during resource cleanup: %v", errs)
+	}
+
+	log.Println("Server has exited")
+	done() //通知主流程关闭完成
+}
+
+// dumpGoroutineStacks writes every goroutine's stack trace to the log, for
+// diagnosing a shutdown hook or handler that's wedged.
+// 将所有goroutine的调用栈写入日志,用于诊断卡死的关闭钩子或处理器
+func dumpGoroutineStacks() {
+	buf := make([]byte, 1<<20)
+	n := stdruntime.Stack(buf, true)
+	log.Printf("goroutine dump:\n%s", buf[:n])
+}
+
+// registerLifecycleEndpoints wires the Kubernetes probe endpoints onto the
+// router. /healthz and /livez report process liveness unconditionally;
+// /readyz reflects the mutable readiness flag so it can be pulled out of
+// service endpoints ahead of an actual shutdown.
+// 将K8s探针端点注册到路由:/healthz与/livez无条件表示进程存活,
+// /readyz反映可变的就绪状态,以便在真正关闭前提前从服务端点摘除
+func registerLifecycleEndpoints(router *gin.Engine, ready *atomic.Bool) {
+	router.GET("/healthz", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	router.GET("/livez", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	router.GET("/readyz", func(c *gin.Context) {
+		if ready.Load() {
+			c.Status(http.StatusOK)
+			return
+		}
+		c.Status(http.StatusServiceUnavailable)
+	})
+}
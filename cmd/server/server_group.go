@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/http/pprof" //暴露pprof性能剖析端点
+	"sync"
+
+	"google.golang.org/grpc" //gRPC服务器
+
+	"github.com/prometheus/client_golang/prometheus/promhttp" //Prometheus指标端点
+)
+
+// managedServer is anything the serverGroup can run and gracefully stop. Each
+// listener WeKnora exposes (HTTP API, gRPC, admin/metrics) implements it so
+// they can share one signal handler and one shutdown sequence.
+// 任何可以被serverGroup运行和优雅停止的服务器都需实现该接口,
+// WeKnora暴露的每个监听器(HTTP API、gRPC、管理/指标)都实现它,以便共享同一套信号处理和关闭流程
+type managedServer interface {
+	// Name identifies the server in logs.
+	Name() string
+	// Serve blocks until the server stops. A clean stop (triggered by
+	// Shutdown) must return nil, not an error.
+	Serve() error
+	// Shutdown stops the server, respecting ctx's deadline where possible.
+	Shutdown(ctx context.Context) error
+}
+
+// httpManagedServer adapts *http.Server to managedServer.
+// httpManagedServer将*http.Server适配为managedServer
+type httpManagedServer struct {
+	name     string
+	server   *http.Server
+	listener net.Listener
+}
+
+// newHTTPManagedServer builds an HTTP-based managed server bound to listener.
+// 基于listener构建一个HTTP类型的托管服务器
+func newHTTPManagedServer(name string, server *http.Server, listener net.Listener) *httpManagedServer {
+	return &httpManagedServer{name: name, server: server, listener: listener}
+}
+
+func (s *httpManagedServer) Name() string { return s.name }
+
+func (s *httpManagedServer) Serve() error {
+	err := s.server.Serve(s.listener)
+	if err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+func (s *httpManagedServer) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
+
+// grpcManagedServer adapts *grpc.Server to managedServer.
+// grpcManagedServer将*grpc.Server适配为managedServer
+type grpcManagedServer struct {
+	name     string
+	server   *grpc.Server
+	listener net.Listener
+}
+
+// newGRPCManagedServer builds a gRPC-based managed server bound to listener.
+// 基于listener构建一个gRPC类型的托管服务器
+func newGRPCManagedServer(name string, server *grpc.Server, listener net.Listener) *grpcManagedServer {
+	return &grpcManagedServer{name: name, server: server, listener: listener}
+}
+
+func (s *grpcManagedServer) Name() string { return s.name }
+
+func (s *grpcManagedServer) Serve() error {
+	return s.server.Serve(s.listener)
+}
+
+func (s *grpcManagedServer) Shutdown(ctx context.Context) error {
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		// Ran out of patience: force-close active streams rather than hang.
+		s.server.Stop()
+		return ctx.Err()
+	}
+}
+
+// newAdminMux builds the admin/metrics HTTP handler: Prometheus metrics plus
+// Go's standard pprof profiling endpoints.
+// 构建管理/指标HTTP处理器:Prometheus指标加上Go标准库的pprof性能剖析端点
+func newAdminMux() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	return mux
+}
+
+// serverGroup runs a fixed set of managedServers under one shared lifecycle:
+// they start together, and if any one of them fails (Serve returns an error
+// or panics) the rest are torn down too rather than left serving traffic no
+// one is watching. A clean stop (Serve returning nil) is not reported.
+// serverGroup在同一套共享生命周期下运行一组managedServer:它们一起启动,
+// 一旦其中任意一个失败(Serve返回错误或panic),其余的也会被一并关闭(正常停止即Serve返回nil不会上报),
+// 而不是让无人监控的服务器继续提供流量
+type serverGroup struct {
+	servers []managedServer
+
+	mu       sync.Mutex
+	fatalErr error
+}
+
+// newServerGroup creates an empty serverGroup; call Register to add servers
+// before calling Run.
+// 创建一个空的serverGroup;在调用Run之前通过Register添加服务器
+func newServerGroup() *serverGroup {
+	return &serverGroup{}
+}
+
+// Register adds a server to the group. Must be called before Run.
+// 将一个服务器加入组,必须在Run之前调用
+func (g *serverGroup) Register(s managedServer) {
+	g.servers = append(g.servers, s)
+}
+
+// Run starts every registered server in its own goroutine and returns a
+// channel that receives the first fatal error (from a Serve failure or a
+// recovered panic). The channel is closed-over and only ever fires once.
+// 为每个已注册的服务器启动独立的goroutine,并返回一个channel,
+// 该channel会接收第一个致命错误(来自Serve失败或被恢复的panic),且只会触发一次
+func (g *serverGroup) Run() <-chan error {
+	fatal := make(chan error, 1)
+	for _, s := range g.servers {
+		s := s
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					g.reportFatal(fatal, fmt.Errorf("panic in %s server: %v", s.Name(), r))
+				}
+			}()
+			log.Printf("%s server is running", s.Name())
+			if err := s.Serve(); err != nil {
+				g.reportFatal(fatal, fmt.Errorf("%s server stopped: %w", s.Name(), err))
+			}
+		}()
+	}
+	return fatal
+}
+
+// reportFatal records the first fatal error and forwards it, ignoring any
+// that follow so a cascade of failures from the shutdown-in-progress servers
+// doesn't block on an unread channel.
+// 记录第一个致命错误并转发,之后的错误会被忽略,避免关闭过程中级联产生的错误阻塞在无人读取的channel上
+func (g *serverGroup) reportFatal(fatal chan<- error, err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.fatalErr != nil {
+		return
+	}
+	g.fatalErr = err
+	select {
+	case fatal <- err:
+	default:
+	}
+}
+
+// Shutdown stops every registered server concurrently and waits for them
+// all to finish (or for ctx to expire).
+// 并发停止所有已注册的服务器,并等待全部完成(或等到ctx超时)
+func (g *serverGroup) Shutdown(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, s := range g.servers {
+		s := s
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.Shutdown(ctx); err != nil {
+				log.Printf("%s server shutdown error: %v", s.Name(), err)
+			}
+		}()
+	}
+	wg.Wait()
+}
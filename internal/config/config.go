@@ -0,0 +1,58 @@
+// Package config holds the application's runtime configuration structs.
+// Fields default to their Go zero value when unset; callers that need a
+// non-zero default (e.g. timeouts) apply it themselves at the point of use,
+// the same way cfg.Server.ShutdownTimeout already does in cmd/server.
+// config包存放应用的运行时配置结构体。字段未设置时为Go零值,
+// 需要非零默认值的调用方(如各类超时)在使用处自行兜底,
+// 与cmd/server中cfg.Server.ShutdownTimeout的处理方式保持一致
+package config
+
+import "time"
+
+// Config is the top-level application configuration, populated by the
+// dependency injection container and passed into main's invoke function.
+// Config是应用的顶层配置,由依赖注入容器填充后传入main的invoke函数
+type Config struct {
+	// Server holds the HTTP API server's own settings.
+	Server ServerConfig
+	// GRPC holds the gRPC server's settings.
+	GRPC GRPCConfig
+	// Admin holds the admin/metrics server's settings.
+	Admin AdminConfig
+}
+
+// ServerConfig configures the HTTP API server and the shutdown behavior
+// shared across all of WeKnora's listeners.
+// ServerConfig配置HTTP API服务器,以及WeKnora所有监听器共用的关闭行为
+type ServerConfig struct {
+	// Host is the address the HTTP, gRPC and admin listeners all bind to.
+	Host string
+	// Port is the HTTP API server's listening port.
+	Port int
+	// ShutdownTimeout bounds how long resource cleanup gets on shutdown.
+	// Zero means the caller falls back to its own default (30s).
+	ShutdownTimeout time.Duration
+	// PreStopDelay is how long the server keeps serving traffic after
+	// reporting not-ready, giving kube-proxy time to remove the pod from
+	// service endpoints before the listener actually drains. Zero means
+	// the caller falls back to its own default (5s).
+	// PreStopDelay是服务器上报未就绪后继续处理流量的时长,
+	// 让kube-proxy有时间将该Pod从服务端点中摘除,之后监听器才真正开始排空。
+	// 为零时调用方回退到自身默认值(5秒)
+	PreStopDelay time.Duration
+}
+
+// GRPCConfig configures the gRPC server that runs alongside the HTTP API.
+// GRPCConfig配置与HTTP API并行运行的gRPC服务器
+type GRPCConfig struct {
+	// Port is the gRPC server's listening port.
+	Port int
+}
+
+// AdminConfig configures the admin/metrics server exposing Prometheus
+// metrics and pprof profiling endpoints.
+// AdminConfig配置暴露Prometheus指标和pprof性能剖析端点的管理服务器
+type AdminConfig struct {
+	// Port is the admin/metrics server's listening port.
+	Port int
+}
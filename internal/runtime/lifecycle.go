@@ -0,0 +1,243 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// Component is a unit of startup/shutdown work with an explicit, named set
+// of dependencies. Lifecycle starts components in dependency order and stops
+// them in the reverse order, so subsystems like model pre-warm, vector index
+// load, or cache hydration can declare what they need without main having to
+// hard-code the sequence.
+type Component struct {
+	// Name identifies the component; it's what other components reference
+	// in DependsOn and what shows up in start/stop logs.
+	Name string
+	// DependsOn lists the names of components that must finish starting
+	// before this one starts, and that must stop after this one stops.
+	DependsOn []string
+	// Start runs the component's startup logic. May be nil for a component
+	// that only needs an ordered Stop (e.g. pure cleanup).
+	Start func(ctx context.Context) error
+	// Stop runs the component's shutdown logic. May be nil for a component
+	// that has nothing to release.
+	Stop func(ctx context.Context) error
+}
+
+// Lifecycle is an egroup-style startup/shutdown manager: components register
+// with their dependencies, Run starts them in topological order (rolling
+// back whatever already started if one fails), and Stop tears down whatever
+// is currently started in reverse order.
+type Lifecycle struct {
+	mu         sync.Mutex
+	components map[string]Component
+	started    []string
+}
+
+// NewLifecycle creates an empty Lifecycle manager.
+func NewLifecycle() *Lifecycle {
+	return &Lifecycle{components: make(map[string]Component)}
+}
+
+// Register adds a component. It must be called before Run and the component
+// name must be unique and not already registered.
+func (l *Lifecycle) Register(c Component) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if c.Name == "" {
+		return fmt.Errorf("runtime: component must have a name")
+	}
+	if _, exists := l.components[c.Name]; exists {
+		return fmt.Errorf("runtime: component %q already registered", c.Name)
+	}
+	l.components[c.Name] = c
+	return nil
+}
+
+// Run starts every registered component in dependency order. If any
+// component fails to start, Run stops the components that already started
+// (in reverse order) before returning the error, so a failed startup never
+// leaves a partial set of subsystems running.
+func (l *Lifecycle) Run(ctx context.Context) error {
+	l.mu.Lock()
+	order, err := topoSort(l.components)
+	l.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range order {
+		comp := l.components[name]
+		if comp.Start != nil {
+			log.Printf("runtime: starting component %q", name)
+			if err := comp.Start(ctx); err != nil {
+				log.Printf("runtime: component %q failed to start: %v, rolling back", name, err)
+				l.Stop(ctx)
+				return fmt.Errorf("runtime: component %q failed to start: %w", name, err)
+			}
+		}
+		l.mu.Lock()
+		l.started = append(l.started, name)
+		l.mu.Unlock()
+	}
+	return nil
+}
+
+// Stop stops every currently-started component in reverse start order. It
+// collects and returns every error encountered rather than stopping at the
+// first one, so one stuck component can't prevent the rest from shutting
+// down cleanly.
+func (l *Lifecycle) Stop(ctx context.Context) []error {
+	l.mu.Lock()
+	started := l.started
+	l.started = nil
+	l.mu.Unlock()
+
+	var errs []error
+	for i := len(started) - 1; i >= 0; i-- {
+		name := started[i]
+		comp := l.components[name]
+		if comp.Stop == nil {
+			continue
+		}
+		log.Printf("runtime: stopping component %q", name)
+		if err := comp.Stop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("runtime: component %q failed to stop: %w", name, err))
+		}
+	}
+	return errs
+}
+
+// topoSort orders components so every component comes after everything it
+// depends on, using Kahn's algorithm. It returns an error if a dependency is
+// missing or a cycle is detected.
+func topoSort(components map[string]Component) ([]string, error) {
+	inDegree := make(map[string]int, len(components))
+	dependents := make(map[string][]string, len(components))
+	for name := range components {
+		inDegree[name] = 0
+	}
+	for name, comp := range components {
+		for _, dep := range comp.DependsOn {
+			if _, ok := components[dep]; !ok {
+				return nil, fmt.Errorf("runtime: component %q depends on unregistered component %q", name, dep)
+			}
+			inDegree[name]++
+			dependents[dep] = append(dependents[dep], name)
+		}
+	}
+
+	var ready []string
+	for name, degree := range inDegree {
+		if degree == 0 {
+			ready = append(ready, name)
+		}
+	}
+
+	var order []string
+	for len(ready) > 0 {
+		// Sort for deterministic ordering among components with no relative
+		// dependency, rather than relying on Go's random map iteration.
+		name := popSmallest(ready)
+		ready = removeName(ready, name)
+		order = append(order, name)
+		for _, dependent := range dependents[name] {
+			inDegree[dependent]--
+			if inDegree[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	if len(order) != len(components) {
+		return nil, fmt.Errorf("runtime: dependency cycle detected among components")
+	}
+	return order, nil
+}
+
+// popSmallest returns the lexicographically smallest name in names.
+func popSmallest(names []string) string {
+	smallest := names[0]
+	for _, n := range names[1:] {
+		if n < smallest {
+			smallest = n
+		}
+	}
+	return smallest
+}
+
+// removeName returns names with the first occurrence of target removed.
+func removeName(names []string, target string) []string {
+	for i, n := range names {
+		if n == target {
+			return append(names[:i], names[i+1:]...)
+		}
+	}
+	return names
+}
+
+// NewCronComponent builds a Component that runs fn on a fixed interval in its
+// own goroutine, starting on Start and cancelling/draining that goroutine on
+// Stop, so periodic tasks never leak past shutdown.
+func NewCronComponent(name string, dependsOn []string, interval time.Duration, fn func(ctx context.Context)) Component {
+	var (
+		cancel context.CancelFunc
+		wg     sync.WaitGroup
+	)
+	return Component{
+		Name:      name,
+		DependsOn: dependsOn,
+		Start: func(context.Context) error {
+			runCtx, c := context.WithCancel(context.Background())
+			cancel = c
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ticker := time.NewTicker(interval)
+				defer ticker.Stop()
+				for {
+					select {
+					case <-runCtx.Done():
+						return
+					case <-ticker.C:
+						fn(runCtx)
+					}
+				}
+			}()
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			if cancel != nil {
+				cancel()
+			}
+			done := make(chan struct{})
+			go func() {
+				wg.Wait()
+				close(done)
+			}()
+			select {
+			case <-done:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	}
+}
+
+// GetContainer returns this package's DI providers: the constructors that
+// container.BuildContainer wires in so callers can depend on
+// interfaces.ResourceCleaner and *Lifecycle without constructing them by
+// hand.
+// GetContainer返回本包的依赖注入提供者:container.BuildContainer会将这些构造函数
+// 接入容器,使调用方可以直接依赖interfaces.ResourceCleaner和*Lifecycle而无需手动构造
+func GetContainer() []interface{} {
+	return []interface{}{
+		ProvideResourceCleaner,
+		NewLifecycle,
+	}
+}
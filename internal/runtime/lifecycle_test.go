@@ -0,0 +1,135 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLifecycleRunStartsLinearDependenciesInOrder(t *testing.T) {
+	lc := NewLifecycle()
+	var started []string
+
+	register := func(name string, dependsOn ...string) {
+		if err := lc.Register(Component{
+			Name:      name,
+			DependsOn: dependsOn,
+			Start: func(context.Context) error {
+				started = append(started, name)
+				return nil
+			},
+		}); err != nil {
+			t.Fatalf("Register(%q) failed: %v", name, err)
+		}
+	}
+
+	// C depends on B depends on A; registered out of order on purpose.
+	register("C", "B")
+	register("A")
+	register("B", "A")
+
+	if err := lc.Run(context.Background()); err != nil {
+		t.Fatalf("Run() returned error: %v", err)
+	}
+
+	want := []string{"A", "B", "C"}
+	if len(started) != len(want) {
+		t.Fatalf("started = %v, want %v", started, want)
+	}
+	for i, name := range want {
+		if started[i] != name {
+			t.Fatalf("started = %v, want %v", started, want)
+		}
+	}
+}
+
+func TestLifecycleRunDetectsCycle(t *testing.T) {
+	lc := NewLifecycle()
+	mustRegister(t, lc, Component{Name: "A", DependsOn: []string{"B"}})
+	mustRegister(t, lc, Component{Name: "B", DependsOn: []string{"A"}})
+
+	if err := lc.Run(context.Background()); err == nil {
+		t.Fatal("Run() succeeded, want cycle error")
+	}
+}
+
+func TestLifecycleRunReportsMissingDependency(t *testing.T) {
+	lc := NewLifecycle()
+	mustRegister(t, lc, Component{Name: "A", DependsOn: []string{"does-not-exist"}})
+
+	err := lc.Run(context.Background())
+	if err == nil {
+		t.Fatal("Run() succeeded, want missing-dependency error")
+	}
+}
+
+func TestLifecycleRunRollsBackAlreadyStartedComponentsOnFailure(t *testing.T) {
+	lc := NewLifecycle()
+	var stopped []string
+
+	mustRegister(t, lc, Component{
+		Name: "A",
+		Start: func(context.Context) error {
+			return nil
+		},
+		Stop: func(context.Context) error {
+			stopped = append(stopped, "A")
+			return nil
+		},
+	})
+	mustRegister(t, lc, Component{
+		Name:      "B",
+		DependsOn: []string{"A"},
+		Start: func(context.Context) error {
+			return errors.New("boom")
+		},
+		Stop: func(context.Context) error {
+			stopped = append(stopped, "B")
+			return nil
+		},
+	})
+
+	if err := lc.Run(context.Background()); err == nil {
+		t.Fatal("Run() succeeded, want error from component B")
+	}
+
+	// B's Start never succeeded, so only A (which did start) should be
+	// rolled back; B's Stop must not run.
+	if len(stopped) != 1 || stopped[0] != "A" {
+		t.Fatalf("stopped = %v, want [A]", stopped)
+	}
+}
+
+func TestNewCronComponentRunsOnIntervalAndStopsCleanly(t *testing.T) {
+	ticks := make(chan struct{}, 8)
+	comp := NewCronComponent("Cron", nil, time.Millisecond, func(context.Context) {
+		select {
+		case ticks <- struct{}{}:
+		default:
+		}
+	})
+
+	if err := comp.Start(context.Background()); err != nil {
+		t.Fatalf("Start() returned error: %v", err)
+	}
+
+	select {
+	case <-ticks:
+	case <-time.After(time.Second):
+		t.Fatal("cron component never ticked")
+	}
+
+	stopCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := comp.Stop(stopCtx); err != nil {
+		t.Fatalf("Stop() returned error: %v", err)
+	}
+}
+
+func mustRegister(t *testing.T, lc *Lifecycle, c Component) {
+	t.Helper()
+	if err := lc.Register(c); err != nil {
+		t.Fatalf("Register(%q) failed: %v", c.Name, err)
+	}
+}
@@ -0,0 +1,126 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Tencent/WeKnora/internal/types/interfaces"
+)
+
+// defaultCleanupPriority is the tier used by RegisterWithName, for hooks that
+// don't care where they land relative to the rest.
+const defaultCleanupPriority = 0
+
+// cleanupHook is one registered shutdown hook.
+type cleanupHook struct {
+	name     string
+	priority int
+	timeout  time.Duration
+	fn       func(ctx context.Context) error
+}
+
+// ResourceCleaner is the concrete interfaces.ResourceCleaner implementation:
+// hooks are grouped by priority tier and, within a tier, run concurrently on
+// a wait group so a single slow hook can't starve the others.
+type ResourceCleaner struct {
+	mu    sync.Mutex
+	hooks []cleanupHook
+}
+
+// NewResourceCleaner creates an empty ResourceCleaner.
+func NewResourceCleaner() *ResourceCleaner {
+	return &ResourceCleaner{}
+}
+
+// ProvideResourceCleaner adapts NewResourceCleaner to the
+// interfaces.ResourceCleaner type so the DI container can resolve it by
+// interface, the same way main's c.Invoke resolves resourceCleaner
+// interfaces.ResourceCleaner without depending on the concrete type.
+func ProvideResourceCleaner() interfaces.ResourceCleaner {
+	return NewResourceCleaner()
+}
+
+// RegisterWithName implements interfaces.ResourceCleaner.
+func (c *ResourceCleaner) RegisterWithName(name string, fn func() error) {
+	c.RegisterWithNameAndTimeout(name, 0, defaultCleanupPriority, func(context.Context) error {
+		return fn()
+	})
+}
+
+// RegisterWithNameAndTimeout implements interfaces.ResourceCleaner.
+func (c *ResourceCleaner) RegisterWithNameAndTimeout(
+	name string, timeout time.Duration, priority int, fn func(ctx context.Context) error,
+) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.hooks = append(c.hooks, cleanupHook{name: name, priority: priority, timeout: timeout, fn: fn})
+}
+
+// Cleanup implements interfaces.ResourceCleaner: hooks run tier by tier, in
+// ascending priority order, with every hook in a tier started concurrently
+// and waited on before the next tier starts.
+func (c *ResourceCleaner) Cleanup(ctx context.Context) []error {
+	c.mu.Lock()
+	hooks := append([]cleanupHook(nil), c.hooks...)
+	c.mu.Unlock()
+
+	byPriority := make(map[int][]cleanupHook)
+	var priorities []int
+	for _, h := range hooks {
+		if _, ok := byPriority[h.priority]; !ok {
+			priorities = append(priorities, h.priority)
+		}
+		byPriority[h.priority] = append(byPriority[h.priority], h)
+	}
+	sort.Ints(priorities)
+
+	var (
+		errMu sync.Mutex
+		errs  []error
+	)
+	for _, priority := range priorities {
+		var wg sync.WaitGroup
+		for _, h := range byPriority[priority] {
+			h := h
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := c.runHook(ctx, h); err != nil {
+					errMu.Lock()
+					errs = append(errs, err)
+					errMu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+	}
+	return errs
+}
+
+// runHook derives the hook's own timeout from ctx (if one was given) and
+// logs its start, end, duration and any error.
+func (c *ResourceCleaner) runHook(ctx context.Context, h cleanupHook) error {
+	hookCtx := ctx
+	if h.timeout > 0 {
+		var cancel context.CancelFunc
+		hookCtx, cancel = context.WithTimeout(ctx, h.timeout)
+		defer cancel()
+	}
+
+	log.Printf("runtime: cleanup hook %q starting (priority=%d)", h.name, h.priority)
+	start := time.Now()
+	err := h.fn(hookCtx)
+	duration := time.Since(start)
+	if err != nil {
+		log.Printf("runtime: cleanup hook %q failed after %s: %v", h.name, duration, err)
+		return fmt.Errorf("%s: %w", h.name, err)
+	}
+	log.Printf("runtime: cleanup hook %q finished in %s", h.name, duration)
+	return nil
+}
+
+var _ interfaces.ResourceCleaner = (*ResourceCleaner)(nil)
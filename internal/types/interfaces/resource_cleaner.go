@@ -0,0 +1,32 @@
+// Package interfaces holds the shared interfaces that cut across WeKnora's
+// internal packages, so implementations can be swapped without callers
+// depending on a concrete package.
+package interfaces
+
+import (
+	"context"
+	"time"
+)
+
+// ResourceCleaner collects named cleanup hooks registered throughout the
+// application (tracer flush, DB pool close, message queue disconnect, ...)
+// and runs them on shutdown. Implementations are expected to run hooks
+// concurrently in priority tiers - HTTP server drain first, then app
+// services, then infra last - so one slow hook can't starve the rest of the
+// shutdown budget.
+type ResourceCleaner interface {
+	// RegisterWithName registers a cleanup hook with no explicit timeout or
+	// priority. It runs in the default tier and only bounded by the ctx
+	// passed to Cleanup.
+	RegisterWithName(name string, fn func() error)
+
+	// RegisterWithNameAndTimeout registers a cleanup hook that runs with its
+	// own derived timeout and a priority tier. Lower priority values run
+	// earlier; hooks within the same tier run concurrently.
+	RegisterWithNameAndTimeout(name string, timeout time.Duration, priority int, fn func(ctx context.Context) error)
+
+	// Cleanup runs every registered hook, tier by tier, and returns the
+	// errors from any hooks that failed. ctx is the overall ceiling for the
+	// whole cleanup pass.
+	Cleanup(ctx context.Context) []error
+}